@@ -0,0 +1,66 @@
+package oaschecker
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// namedRouteRouter looks up the OpenAPI operation for a request via a
+// framework-specific route identifier (a gorilla/mux route name, a chi route
+// pattern, ...) instead of re-matching r.URL against the spec, which is what
+// lets the middleware sit behind a base path that differs from the spec's
+// `servers` entries.
+type namedRouteRouter struct {
+	doc    *openapi3.T
+	byOpID map[string]namedOperation
+	toOpID map[string]string
+	lookup func(req *http.Request) (routeName string, pathParams map[string]string, ok bool)
+}
+
+type namedOperation struct {
+	path      string
+	method    string
+	pathItem  *openapi3.PathItem
+	operation *openapi3.Operation
+}
+
+func indexOperationsByID(doc *openapi3.T) map[string]namedOperation {
+	index := make(map[string]namedOperation)
+	for path, pathItem := range doc.Paths.Map() {
+		for method, op := range pathItem.Operations() {
+			if op.OperationID == "" {
+				continue
+			}
+			index[op.OperationID] = namedOperation{path: path, method: method, pathItem: pathItem, operation: op}
+		}
+	}
+	return index
+}
+
+func (r *namedRouteRouter) FindRoute(req *http.Request) (*routers.Route, map[string]string, error) {
+	routeName, pathParams, ok := r.lookup(req)
+	if !ok {
+		return nil, nil, fmt.Errorf("oaschecker: request carries no matched route")
+	}
+
+	opID, ok := r.toOpID[routeName]
+	if !ok {
+		return nil, nil, fmt.Errorf("oaschecker: no operationId mapped for route %q", routeName)
+	}
+
+	op, ok := r.byOpID[opID]
+	if !ok {
+		return nil, nil, fmt.Errorf("oaschecker: spec has no operation with operationId %q", opID)
+	}
+
+	return &routers.Route{
+		Spec:      r.doc,
+		Path:      op.path,
+		PathItem:  op.pathItem,
+		Method:    op.method,
+		Operation: op.operation,
+	}, pathParams, nil
+}
@@ -0,0 +1,38 @@
+package oaschecker
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+)
+
+// ChiRoutes builds a Router that identifies the matched operation by the chi
+// route pattern that served the request (as reported by
+// chi.RouteContext(r.Context()).RoutePattern()), rather than by re-matching
+// r.URL against the spec's `servers` entries. patternToOperationID maps each
+// chi route pattern (e.g. "/pets/{id}") to the operationId of the OpenAPI
+// operation it implements.
+func ChiRoutes(doc *openapi3.T, patternToOperationID map[string]string) Router {
+	return &namedRouteRouter{
+		doc:    doc,
+		byOpID: indexOperationsByID(doc),
+		toOpID: patternToOperationID,
+		lookup: func(req *http.Request) (string, map[string]string, bool) {
+			rctx := chi.RouteContext(req.Context())
+			if rctx == nil {
+				return "", nil, false
+			}
+			pattern := rctx.RoutePattern()
+			if pattern == "" {
+				return "", nil, false
+			}
+
+			pathParams := make(map[string]string, len(rctx.URLParams.Keys))
+			for i, key := range rctx.URLParams.Keys {
+				pathParams[key] = rctx.URLParams.Values[i]
+			}
+			return pattern, pathParams, true
+		},
+	}
+}
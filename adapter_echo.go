@@ -0,0 +1,115 @@
+package oaschecker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/labstack/echo/v4"
+)
+
+// EchoMiddleware returns an echo.MiddlewareFunc that validates traffic
+// passing through an Echo route tree, identifying the matched operation via
+// Echo's own c.Path() (its route pattern) rather than by re-matching the
+// request URL against the spec's `servers` entries. pathToOperationID maps
+// each Echo route path (e.g. "/pets/:id") to the operationId of the OpenAPI
+// operation it implements.
+//
+// The returned *Middleware accumulates issues exactly like the one returned
+// by Checker.Middleware, so Validate, Report and Coverage work the same way.
+func (c *Checker) EchoMiddleware(pathToOperationID map[string]string) (echo.MiddlewareFunc, *Middleware, error) {
+	if c.doc == nil {
+		return nil, nil, fmt.Errorf("oaschecker: EchoMiddleware requires a Checker built from a File or Document")
+	}
+
+	router := &echoRouteRouter{
+		byOpID: indexOperationsByID(c.doc),
+		toOpID: pathToOperationID,
+		doc:    c.doc,
+	}
+
+	mw := &Middleware{
+		router:             router,
+		reporter:           c.reporter,
+		maxCapturedBody:    c.maxCapturedBody,
+		opaqueContentTypes: c.opaqueContentTypes,
+		mode:               c.mode,
+		problemFormatter:   c.problemFormatter,
+		coverage:           c.coverage,
+		filterOptions:      c.filterOptions,
+	}
+	mw.next = http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		result := r.Context().Value(echoNextResultKey{}).(*echoNextResult)
+		result.ctx.SetResponse(echo.NewResponse(rw, result.ctx.Echo()))
+		result.err = result.next(result.ctx)
+	})
+
+	echoMW := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ec echo.Context) error {
+			pathParams := make(map[string]string, len(ec.ParamNames()))
+			for i, name := range ec.ParamNames() {
+				pathParams[name] = ec.ParamValues()[i]
+			}
+
+			result := &echoNextResult{ctx: ec, next: next}
+			ctx := context.WithValue(ec.Request().Context(), echoRouteInfoKey{}, echoRouteInfo{
+				path:       ec.Path(),
+				pathParams: pathParams,
+			})
+			ctx = context.WithValue(ctx, echoNextResultKey{}, result)
+
+			mw.ServeHTTP(ec.Response(), ec.Request().WithContext(ctx))
+			return result.err
+		}
+	}
+
+	return echoMW, mw, nil
+}
+
+type echoRouteInfoKey struct{}
+
+type echoRouteInfo struct {
+	path       string
+	pathParams map[string]string
+}
+
+type echoNextResultKey struct{}
+
+type echoNextResult struct {
+	ctx  echo.Context
+	next echo.HandlerFunc
+	err  error
+}
+
+type echoRouteRouter struct {
+	doc    *openapi3.T
+	byOpID map[string]namedOperation
+	toOpID map[string]string
+}
+
+func (r *echoRouteRouter) FindRoute(req *http.Request) (*routers.Route, map[string]string, error) {
+	info, ok := req.Context().Value(echoRouteInfoKey{}).(echoRouteInfo)
+	if !ok {
+		return nil, nil, fmt.Errorf("oaschecker: request carries no Echo route info")
+	}
+
+	opID, ok := r.toOpID[info.path]
+	if !ok {
+		return nil, nil, fmt.Errorf("oaschecker: no operationId mapped for Echo route %q", info.path)
+	}
+
+	op, ok := r.byOpID[opID]
+	if !ok {
+		return nil, nil, fmt.Errorf("oaschecker: spec has no operation with operationId %q", opID)
+	}
+
+	return &routers.Route{
+		Spec:      r.doc,
+		Path:      op.path,
+		PathItem:  op.pathItem,
+		Method:    op.method,
+		Operation: op.operation,
+	}, info.pathParams, nil
+}
@@ -0,0 +1,28 @@
+package oaschecker
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gorilla/mux"
+)
+
+// GorillaMuxRoutes builds a Router that identifies the matched operation by
+// the name of the gorilla/mux route that served the request (see
+// mux.Route.Name), rather than by re-matching r.URL against the spec's
+// `servers` entries. routeNameToOperationID maps each named mux.Route to the
+// operationId of the OpenAPI operation it implements.
+func GorillaMuxRoutes(doc *openapi3.T, routeNameToOperationID map[string]string) Router {
+	return &namedRouteRouter{
+		doc:    doc,
+		byOpID: indexOperationsByID(doc),
+		toOpID: routeNameToOperationID,
+		lookup: func(req *http.Request) (string, map[string]string, bool) {
+			route := mux.CurrentRoute(req)
+			if route == nil || route.GetName() == "" {
+				return "", nil, false
+			}
+			return route.GetName(), mux.Vars(req), true
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package oaschecker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChiRoutes(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(petStore))
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	router := ChiRoutes(doc, map[string]string{
+		"/pets": "listPets",
+	})
+	var issues []ValidationIssue
+	mw := &Middleware{
+		router: router,
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(`[]`))
+		}),
+	}
+	r.Get("/pets", mw.ServeHTTP)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/pets")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	mw.mu.Lock()
+	issues = append([]ValidationIssue(nil), mw.issues...)
+	mw.mu.Unlock()
+	assert.Empty(t, issues, "GET /pets should match the listPets operation and validate cleanly")
+}
+
+func TestChiRoutes_unmappedPattern(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(petStore))
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	router := ChiRoutes(doc, map[string]string{})
+	mw := &Middleware{
+		router: router,
+		next:   http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) { rw.WriteHeader(http.StatusOK) }),
+	}
+	r.Get("/pets", mw.ServeHTTP)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/pets")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "an unmapped route is recorded as an issue, not rejected")
+
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	require.Len(t, mw.issues, 1)
+	assert.Contains(t, mw.issues[0].Description, "Route not found in specification")
+}
+
+func TestGorillaMuxRoutes(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(petStore))
+	require.NoError(t, err)
+
+	r := mux.NewRouter()
+	router := GorillaMuxRoutes(doc, map[string]string{
+		"listPetsRoute": "listPets",
+	})
+	mw := &Middleware{
+		router: router,
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(`[]`))
+		}),
+	}
+	r.Handle("/pets", mw).Methods("GET").Name("listPetsRoute")
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/pets")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	assert.Empty(t, mw.issues, "GET /pets should match the listPets operation via the named mux route")
+}
+
+func TestEchoMiddleware(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(petStore))
+	require.NoError(t, err)
+
+	checker := &Checker{doc: doc, coverage: newCoverageTracker(doc)}
+	echoMW, mw, err := checker.EchoMiddleware(map[string]string{
+		"/pets": "listPets",
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(echoMW)
+	e.GET("/pets", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []string{})
+	})
+
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/pets")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Empty(t, mw.issues, "GET /pets should match the listPets operation via Echo's route path")
+}
+
+func TestEchoMiddleware_requiresDocument(t *testing.T) {
+	checker := &Checker{}
+	_, _, err := checker.EchoMiddleware(map[string]string{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package oaschecker
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func authInput(t *testing.T, method, urlStr string, header http.Header) *openapi3filter.AuthenticationInput {
+	t.Helper()
+	req, err := http.NewRequest(method, urlStr, nil)
+	require.NoError(t, err)
+	if header != nil {
+		req.Header = header
+	}
+	return &openapi3filter.AuthenticationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{Request: req},
+		SecuritySchemeName:     "myScheme",
+		SecurityScheme:         &openapi3.SecurityScheme{},
+	}
+}
+
+func TestStaticBearerToken(t *testing.T) {
+	validator := StaticBearerToken("s3cr3t")
+
+	t.Run("accepts the matching token", func(t *testing.T) {
+		input := authInput(t, "GET", "http://example.com", http.Header{"Authorization": {"Bearer s3cr3t"}})
+		assert.NoError(t, validator(nil, input))
+	})
+
+	t.Run("rejects a mismatched token", func(t *testing.T) {
+		input := authInput(t, "GET", "http://example.com", http.Header{"Authorization": {"Bearer wrong"}})
+		assert.Error(t, validator(nil, input))
+	})
+
+	t.Run("rejects a missing Authorization header", func(t *testing.T) {
+		input := authInput(t, "GET", "http://example.com", nil)
+		assert.Error(t, validator(nil, input))
+	})
+}
+
+func TestJWTBearer(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		// key.E is the standard 65537 public exponent, i.e. "AQAB" in base64url.
+		fmt.Fprintf(rw, `{"keys":[{"kty":"RSA","kid":"key-1","n":%q,"e":"AQAB"}]}`,
+			base64.RawURLEncoding.EncodeToString(key.N.Bytes()))
+	}))
+	defer jwks.Close()
+
+	validator, err := JWTBearer(jwks.URL)
+	require.NoError(t, err)
+
+	sign := func(method jwt.SigningMethod, signingKey interface{}) string {
+		token := jwt.NewWithClaims(method, jwt.MapClaims{})
+		token.Header["kid"] = "key-1"
+		signed, err := token.SignedString(signingKey)
+		require.NoError(t, err)
+		return signed
+	}
+
+	t.Run("accepts a token signed with the published RSA key", func(t *testing.T) {
+		input := authInput(t, "GET", "http://example.com", http.Header{"Authorization": {"Bearer " + sign(jwt.SigningMethodRS256, key)}})
+		assert.NoError(t, validator(nil, input))
+	})
+
+	t.Run("rejects a token signed with an unrelated RSA key", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		input := authInput(t, "GET", "http://example.com", http.Header{"Authorization": {"Bearer " + sign(jwt.SigningMethodRS256, otherKey)}})
+		assert.Error(t, validator(nil, input))
+	})
+
+	t.Run("rejects a token signed with an RSA method outside the default allow-list", func(t *testing.T) {
+		// PS256 validates correctly against the very same RSA key, so only
+		// the parser's restricted method list stands between this and a
+		// successfully "verified" token.
+		input := authInput(t, "GET", "http://example.com", http.Header{"Authorization": {"Bearer " + sign(jwt.SigningMethodPS256, key)}})
+		assert.Error(t, validator(nil, input))
+	})
+}
+
+func TestHMACAPIKey(t *testing.T) {
+	secret := []byte("shh")
+	validator := HMACAPIKey(secret)
+
+	sign := func(path string) string {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(path))
+		return fmt.Sprintf("%x", mac.Sum(nil))
+	}
+
+	t.Run("accepts a header apiKey signed with the secret", func(t *testing.T) {
+		input := authInput(t, "GET", "http://example.com/pets", http.Header{"X-Api-Key": {sign("/pets")}})
+		input.SecurityScheme.In = "header"
+		input.SecurityScheme.Name = "X-Api-Key"
+		assert.NoError(t, validator(nil, input))
+	})
+
+	t.Run("rejects a mismatched signature", func(t *testing.T) {
+		input := authInput(t, "GET", "http://example.com/pets", http.Header{"X-Api-Key": {"deadbeef"}})
+		input.SecurityScheme.In = "header"
+		input.SecurityScheme.Name = "X-Api-Key"
+		assert.Error(t, validator(nil, input))
+	})
+
+	t.Run("accepts a query apiKey signed with the secret", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "http://example.com/pets?key="+url.QueryEscape(sign("/pets")), nil)
+		require.NoError(t, err)
+		input := &openapi3filter.AuthenticationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{Request: req},
+			SecuritySchemeName:     "myScheme",
+			SecurityScheme:         &openapi3.SecurityScheme{In: "query", Name: "key"},
+		}
+		assert.NoError(t, validator(nil, input))
+	})
+
+	t.Run("rejects an unsupported location", func(t *testing.T) {
+		input := authInput(t, "GET", "http://example.com/pets", nil)
+		input.SecurityScheme.In = "unsupported"
+		input.SecurityScheme.Name = "X-Api-Key"
+		assert.Error(t, validator(nil, input))
+	})
+}
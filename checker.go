@@ -1,28 +1,151 @@
 package oaschecker
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
 )
 
 type Options struct {
+	// File is the path to the OpenAPI spec to load. It's a thin wrapper
+	// around FileSource; set Source instead to load from a URL, an
+	// embed.FS, or raw bytes. Ignored if Source, Document or Router is set.
 	File string
+	// Source loads the spec, resolving any `$ref`s relative to wherever it
+	// came from. Takes precedence over File, and is ignored if Document or
+	// Router is set.
+	Source Source
+	// Document is an already-loaded spec. Takes precedence over File and
+	// Source for loading the Router. Set this even when supplying Router
+	// directly: it's still used to seed coverage tracking and is required
+	// by EchoMiddleware.
+	Document *openapi3.T
+	// Router matches requests to operations. When set, it is used as-is and
+	// File is ignored. Use this to pick a non-default implementation, e.g.
+	// NewGorillaMuxRouter, or one of the framework adapters. Document must
+	// still be set alongside it; see Document.
+	Router   Router
+	Reporter Reporter
+
+	// MaxCapturedBody caps how many response body bytes are buffered for
+	// validation; bytes beyond the cap are still streamed to the client but
+	// are not validated, and a "response body truncated" issue is recorded
+	// instead. Under ModeRejectResponse or ModeStrict the response is held
+	// rather than streamed, so an over-cap response is replaced with a 500
+	// problem document instead. Defaults to DefaultMaxCapturedBody.
+	MaxCapturedBody int64
+	// OpaqueContentTypes lists response Content-Types (e.g.
+	// "application/octet-stream", "text/event-stream") that should never be
+	// captured or validated, however small.
+	OpaqueContentTypes []string
+
+	// Mode controls whether invalid traffic is only recorded as an issue
+	// (ModeObserve, the default) or also rejected.
+	Mode Mode
+	// ProblemFormatter customizes the RFC 7807 document written to the
+	// client when Mode rejects a request or response. Defaults to a minimal
+	// formatter that just fills in title, status and the validation error.
+	ProblemFormatter ProblemFormatter
+
+	// AuthenticationFunc enforces the spec's `security:` requirements by
+	// validating each security scheme a request claims to satisfy. See
+	// AuthValidators for composing per-scheme validators, and
+	// StaticBearerToken, JWTBearer and HMACAPIKey for built-in ones.
+	AuthenticationFunc openapi3filter.AuthenticationFunc
+	// ExcludeRequestBody skips request body validation entirely, e.g. for
+	// APIs that accept unstructured or very large request payloads.
+	ExcludeRequestBody bool
+	// ExcludeResponseBody skips response body validation entirely.
+	ExcludeResponseBody bool
+	// IncludeResponseStatus makes response validation also check the
+	// response's status code against the spec's declared responses, failing
+	// if the status code isn't documented at all.
+	IncludeResponseStatus bool
 }
 
 func New(opt Options) (*Checker, error) {
-	router := openapi3filter.NewRouter()
-	if err := router.AddSwaggerFromFile(opt.File); err != nil {
-		return nil, err
+	doc := opt.Document
+	router := opt.Router
+	if router == nil {
+		if doc == nil {
+			source := opt.Source
+			if source == nil {
+				source = FileSource(opt.File)
+			}
+
+			loaded, err := source.load(&openapi3.Loader{IsExternalRefsAllowed: true})
+			if err != nil {
+				return nil, err
+			}
+			doc = loaded
+		}
+
+		if err := doc.Validate(context.Background()); err != nil {
+			return nil, err
+		}
+
+		r, err := NewLegacyRouter(doc)
+		if err != nil {
+			return nil, err
+		}
+		router = r
 	}
 
-	return &Checker{router: router}, nil
+	maxCapturedBody := opt.MaxCapturedBody
+	if maxCapturedBody <= 0 {
+		maxCapturedBody = DefaultMaxCapturedBody
+	}
+
+	opaqueContentTypes := make(map[string]bool, len(opt.OpaqueContentTypes))
+	for _, ct := range opt.OpaqueContentTypes {
+		opaqueContentTypes[normalizeContentType(ct)] = true
+	}
+
+	return &Checker{
+		doc:                doc,
+		router:             router,
+		reporter:           opt.Reporter,
+		maxCapturedBody:    maxCapturedBody,
+		opaqueContentTypes: opaqueContentTypes,
+		mode:               opt.Mode,
+		problemFormatter:   opt.ProblemFormatter,
+		coverage:           newCoverageTracker(doc),
+		filterOptions: &openapi3filter.Options{
+			AuthenticationFunc:    opt.AuthenticationFunc,
+			ExcludeRequestBody:    opt.ExcludeRequestBody,
+			ExcludeResponseBody:   opt.ExcludeResponseBody,
+			IncludeResponseStatus: opt.IncludeResponseStatus,
+		},
+	}, nil
 }
 
+// Checker holds an OpenAPI spec and the Router used to match traffic against
+// it. Use Middleware, or one of the framework-specific adapters
+// (GorillaMuxRoutes, ChiRoutes, EchoMiddleware), to validate real traffic.
 type Checker struct {
-	router *openapi3filter.Router
+	doc                *openapi3.T
+	router             Router
+	reporter           Reporter
+	maxCapturedBody    int64
+	opaqueContentTypes map[string]bool
+	mode               Mode
+	problemFormatter   ProblemFormatter
+	coverage           *coverageTracker
+	filterOptions      *openapi3filter.Options
 }
 
 func (c *Checker) Middleware(next http.Handler) *Middleware {
-	return &Middleware{router: c.router, next: next}
+	return &Middleware{
+		router:             c.router,
+		reporter:           c.reporter,
+		maxCapturedBody:    c.maxCapturedBody,
+		opaqueContentTypes: c.opaqueContentTypes,
+		mode:               c.mode,
+		problemFormatter:   c.problemFormatter,
+		coverage:           c.coverage,
+		filterOptions:      c.filterOptions,
+		next:               next,
+	}
 }
@@ -0,0 +1,88 @@
+package oaschecker
+
+import (
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// CoverageReport summarizes, for every (method, path template) operation in
+// the loaded spec, whether it was exercised by traffic passing through a
+// Middleware, and how many times each response status code was observed.
+type CoverageReport struct {
+	Operations []OperationCoverage
+}
+
+// OperationCoverage describes the coverage of a single spec operation.
+type OperationCoverage struct {
+	Method  string
+	Path    string
+	Covered bool
+	// Hits maps an observed response status code to the number of times it
+	// was seen.
+	Hits map[int]int
+}
+
+type operationKey struct {
+	Method string
+	Path   string
+}
+
+// coverageTracker records, for every operation known at construction time,
+// whether and how it has been exercised. It is shared by every Middleware a
+// Checker creates, so coverage accumulates across all of them.
+type coverageTracker struct {
+	mu   sync.Mutex
+	hits map[operationKey]map[int]int
+}
+
+func newCoverageTracker(doc *openapi3.T) *coverageTracker {
+	hits := make(map[operationKey]map[int]int)
+	if doc != nil {
+		for path, pathItem := range doc.Paths.Map() {
+			for method := range pathItem.Operations() {
+				hits[operationKey{Method: method, Path: path}] = make(map[int]int)
+			}
+		}
+	}
+	return &coverageTracker{hits: hits}
+}
+
+func (t *coverageTracker) record(method, path string, statusCode int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := operationKey{Method: method, Path: path}
+	byStatus, ok := t.hits[key]
+	if !ok {
+		byStatus = make(map[int]int)
+		t.hits[key] = byStatus
+	}
+	byStatus[statusCode]++
+}
+
+func (t *coverageTracker) report() CoverageReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	operations := make([]OperationCoverage, 0, len(t.hits))
+	for key, byStatus := range t.hits {
+		hits := make(map[int]int, len(byStatus))
+		for status, count := range byStatus {
+			hits[status] = count
+		}
+		operations = append(operations, OperationCoverage{
+			Method:  key.Method,
+			Path:    key.Path,
+			Covered: len(byStatus) > 0,
+			Hits:    hits,
+		})
+	}
+	return CoverageReport{Operations: operations}
+}
+
+// Coverage returns a snapshot of which spec operations have been exercised
+// by traffic passing through this Checker's middlewares so far.
+func (c *Checker) Coverage() CoverageReport {
+	return c.coverage.report()
+}
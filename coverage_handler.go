@@ -0,0 +1,125 @@
+package oaschecker
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+)
+
+// CoverageHandler serves the Checker's current CoverageReport. The response
+// format is chosen with the `format` query parameter: "json" (the default),
+// "cobertura", or "lcov".
+func (c *Checker) CoverageHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		report := c.Coverage()
+
+		switch r.URL.Query().Get("format") {
+		case "cobertura":
+			writeCoberturaCoverage(rw, report)
+		case "lcov":
+			writeLCOVCoverage(rw, report)
+		default:
+			writeJSONCoverage(rw, report)
+		}
+	})
+}
+
+func sortedOperations(report CoverageReport) []OperationCoverage {
+	operations := append([]OperationCoverage(nil), report.Operations...)
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].Path != operations[j].Path {
+			return operations[i].Path < operations[j].Path
+		}
+		return operations[i].Method < operations[j].Method
+	})
+	return operations
+}
+
+func writeJSONCoverage(rw http.ResponseWriter, report CoverageReport) {
+	rw.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(CoverageReport{Operations: sortedOperations(report)})
+}
+
+// Cobertura's schema is line-coverage oriented; each operation is modeled as
+// a <class> with one <line> per observed response status code, carrying the
+// number of times it was seen. An operation with no observed traffic gets a
+// single <line> with 0 hits; status codes the spec declares but that were
+// never observed get no line.
+func writeCoberturaCoverage(rw http.ResponseWriter, report CoverageReport) {
+	type coberturaLine struct {
+		Number int `xml:"number,attr"`
+		Hits   int `xml:"hits,attr"`
+	}
+	type coberturaClass struct {
+		Name     string          `xml:"name,attr"`
+		Filename string          `xml:"filename,attr"`
+		Lines    []coberturaLine `xml:"lines>line"`
+	}
+	type coberturaPackage struct {
+		Name    string           `xml:"name,attr"`
+		Classes []coberturaClass `xml:"classes>class"`
+	}
+	type coberturaCoverage struct {
+		XMLName  xml.Name           `xml:"coverage"`
+		Packages []coberturaPackage `xml:"packages>package"`
+	}
+
+	operations := sortedOperations(report)
+	pkg := coberturaPackage{Name: "oaschecker"}
+	for _, op := range operations {
+		class := coberturaClass{
+			Name:     fmt.Sprintf("%s %s", op.Method, op.Path),
+			Filename: op.Path,
+		}
+		statusCodes := make([]int, 0, len(op.Hits))
+		for statusCode := range op.Hits {
+			statusCodes = append(statusCodes, statusCode)
+		}
+		sort.Ints(statusCodes)
+		for i, statusCode := range statusCodes {
+			class.Lines = append(class.Lines, coberturaLine{Number: i + 1, Hits: op.Hits[statusCode]})
+		}
+		if len(class.Lines) == 0 {
+			class.Lines = append(class.Lines, coberturaLine{Number: 1, Hits: 0})
+		}
+		pkg.Classes = append(pkg.Classes, class)
+	}
+
+	rw.Header().Set("Content-Type", "application/xml")
+	io.WriteString(rw, xml.Header)
+	enc := xml.NewEncoder(rw)
+	enc.Indent("", "  ")
+	enc.Encode(coberturaCoverage{Packages: []coberturaPackage{pkg}})
+}
+
+// LCOV models each operation as a source file (SF) with one DA (line
+// execution count) line per observed response status code.
+func writeLCOVCoverage(rw http.ResponseWriter, report CoverageReport) {
+	rw.Header().Set("Content-Type", "text/plain")
+
+	for _, op := range sortedOperations(report) {
+		fmt.Fprintf(rw, "SF:%s %s\n", op.Method, op.Path)
+
+		statusCodes := make([]int, 0, len(op.Hits))
+		for statusCode := range op.Hits {
+			statusCodes = append(statusCodes, statusCode)
+		}
+		sort.Ints(statusCodes)
+
+		for i, statusCode := range statusCodes {
+			fmt.Fprintf(rw, "DA:%d,%d\n", i+1, op.Hits[statusCode])
+		}
+		fmt.Fprintf(rw, "LF:%d\n", len(statusCodes))
+		hit := 0
+		for _, statusCode := range statusCodes {
+			if op.Hits[statusCode] > 0 {
+				hit++
+			}
+		}
+		fmt.Fprintf(rw, "LH:%d\n", hit)
+		fmt.Fprintln(rw, "end_of_record")
+	}
+}
@@ -0,0 +1,92 @@
+package oaschecker
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCoverageChecker() *Checker {
+	tracker := &coverageTracker{hits: map[operationKey]map[int]int{
+		{Method: "GET", Path: "/pets"}:    {200: 2},
+		{Method: "POST", Path: "/pets"}:   {},
+		{Method: "DELETE", Path: "/pets"}: {404: 1, 204: 3},
+	}}
+	return &Checker{coverage: tracker}
+}
+
+func TestCoverageHandler_JSON(t *testing.T) {
+	handler := newTestCoverageChecker().CoverageHandler()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/coverage", nil)
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var report CoverageReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.Len(t, report.Operations, 3)
+
+	// Operations should be sorted by path, then method, regardless of the
+	// map iteration order they were tracked in.
+	assert.Equal(t, []string{"DELETE /pets", "GET /pets", "POST /pets"}, operationLabels(report.Operations))
+}
+
+func TestCoverageHandler_JSON_isDeterministic(t *testing.T) {
+	handler := newTestCoverageChecker().CoverageHandler()
+
+	var bodies []string
+	for i := 0; i < 5; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/coverage", nil))
+		bodies = append(bodies, rec.Body.String())
+	}
+
+	for _, body := range bodies[1:] {
+		assert.Equal(t, bodies[0], body, "JSON coverage output should be stable across calls")
+	}
+}
+
+func TestCoverageHandler_Cobertura(t *testing.T) {
+	handler := newTestCoverageChecker().CoverageHandler()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/coverage?format=cobertura", nil)
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "application/xml", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	assert.Contains(t, body, `<coverage>`)
+	assert.Contains(t, body, `name="GET /pets"`)
+	assert.Contains(t, body, `name="POST /pets"`)
+	assert.True(t, strings.Index(body, `name="DELETE /pets"`) < strings.Index(body, `name="GET /pets"`),
+		"classes should be emitted in sorted order")
+}
+
+func TestCoverageHandler_LCOV(t *testing.T) {
+	handler := newTestCoverageChecker().CoverageHandler()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/coverage?format=lcov", nil)
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	assert.Contains(t, body, "SF:GET /pets")
+	assert.Contains(t, body, "end_of_record")
+	assert.True(t, strings.Index(body, "SF:DELETE /pets") < strings.Index(body, "SF:GET /pets"),
+		"records should be emitted in sorted order")
+}
+
+func operationLabels(operations []OperationCoverage) []string {
+	labels := make([]string, len(operations))
+	for i, op := range operations {
+		labels[i] = op.Method + " " + op.Path
+	}
+	return labels
+}
@@ -0,0 +1,52 @@
+package oaschecker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Coverage(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(petStore))
+	require.NoError(t, err)
+
+	router, err := NewLegacyRouter(doc)
+	require.NoError(t, err)
+
+	checker := &Checker{doc: doc, router: router, coverage: newCoverageTracker(doc)}
+
+	middleware := checker.Middleware(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusOK)
+		rw.Write([]byte(`[{"id": 123, "name": "Buddy"}]`))
+	}))
+
+	req, err := http.NewRequest("GET", "http://petstore.swagger.io/v1/pets", nil)
+	require.NoError(t, err)
+
+	middleware.ServeHTTP(httptest.NewRecorder(), req)
+
+	report := checker.Coverage()
+
+	var listPets, createPets *OperationCoverage
+	for i := range report.Operations {
+		op := &report.Operations[i]
+		switch {
+		case op.Method == "GET" && op.Path == "/pets":
+			listPets = op
+		case op.Method == "POST" && op.Path == "/pets":
+			createPets = op
+		}
+	}
+
+	require.NotNil(t, listPets, "GET /pets should be a known operation")
+	assert.True(t, listPets.Covered)
+	assert.Equal(t, 1, listPets.Hits[http.StatusOK])
+
+	require.NotNil(t, createPets, "POST /pets should be a known operation")
+	assert.False(t, createPets.Covered, "POST /pets was never exercised")
+}
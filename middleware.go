@@ -1,28 +1,41 @@
 package oaschecker
 
 import (
-	"bytes"
 	"fmt"
 	"net/http"
-	"net/http/httptest"
 	"net/url"
 	"strings"
 	"sync"
 
 	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
 )
 
 var _ http.Handler = &Middleware{}
 
 type Middleware struct {
-	router *openapi3filter.Router
-	next   http.Handler
-	mu     sync.Mutex
-	issues []validationIssue
+	router             Router
+	reporter           Reporter
+	maxCapturedBody    int64
+	opaqueContentTypes map[string]bool
+	mode               Mode
+	problemFormatter   ProblemFormatter
+	coverage           *coverageTracker
+	filterOptions      *openapi3filter.Options
+	next               http.Handler
+	mu                 sync.Mutex
+	issues             []ValidationIssue
+}
+
+func (c *Middleware) recordCoverage(route *routers.Route, statusCode int) {
+	if c.coverage == nil {
+		return
+	}
+	c.coverage.record(route.Method, route.Path, statusCode)
 }
 
 func (c *Middleware) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	route, pathParams, err := c.router.FindRoute(r.Method, r.URL)
+	route, pathParams, err := c.router.FindRoute(r)
 	if err != nil {
 		c.addIssue(r.Method, r.URL, fmt.Sprintf("Route not found in specification: %v", err))
 		c.next.ServeHTTP(rw, r)
@@ -33,34 +46,56 @@ func (c *Middleware) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		Request:    r,
 		PathParams: pathParams,
 		Route:      route,
+		Options:    c.filterOptions,
 	}
 	if err := openapi3filter.ValidateRequest(r.Context(), reqValInput); err != nil {
 		c.addIssue(r.Method, r.URL, fmt.Sprintf("Invalid request: %v", err))
+		if c.mode.rejectsRequest() {
+			writeProblem(rw, r, c.problemFormatter, http.StatusBadRequest, "Request does not match the API specification", err)
+			c.recordCoverage(route, http.StatusBadRequest)
+			return
+		}
 	}
 
-	recorder := httptest.NewRecorder()
-	c.next.ServeHTTP(recorder, r)
-	for k, v := range recorder.HeaderMap {
-		rw.Header()[k] = v
+	rejectResponse := c.mode.rejectsResponse()
+	cw := newCapturingResponseWriter(rw, c.maxCapturedBody, c.opaqueContentTypes, rejectResponse)
+	c.next.ServeHTTP(cw, r)
+
+	if cw.truncated {
+		c.addIssue(r.Method, r.URL, "Response body truncated, not validated")
+		if rejectResponse {
+			writeProblem(rw, r, c.problemFormatter, http.StatusInternalServerError, "Response exceeded the validator's body size limit", nil)
+			c.recordCoverage(route, http.StatusInternalServerError)
+		} else {
+			cw.commit()
+			c.recordCoverage(route, cw.statusCode)
+		}
+		return
+	}
+	if cw.opaque || cw.captured.Len() == 0 {
+		cw.commit()
+		c.recordCoverage(route, cw.statusCode)
+		return
 	}
-	rw.WriteHeader(recorder.Code)
-
-	bytes.NewBuffer(recorder.Body.Bytes()).WriteTo(rw)
 
 	resValInput := &openapi3filter.ResponseValidationInput{
 		RequestValidationInput: reqValInput,
-		Status:                 recorder.Code,
-		Header:                 recorder.HeaderMap,
+		Status:                 cw.statusCode,
+		Header:                 cw.Header(),
+		Options:                c.filterOptions,
 	}
-
-	bodyBytes := recorder.Body.Bytes()
-	if len(bodyBytes) > 0 {
-		resValInput.SetBodyBytes(recorder.Body.Bytes())
-
-		if err := openapi3filter.ValidateResponse(r.Context(), resValInput); err != nil {
-			c.addIssue(r.Method, r.URL, fmt.Sprintf("Invalid response: %v", err))
+	resValInput.SetBodyBytes(cw.captured.Bytes())
+
+	if err := openapi3filter.ValidateResponse(r.Context(), resValInput); err != nil {
+		c.addIssue(r.Method, r.URL, fmt.Sprintf("Invalid response: %v", err))
+		if rejectResponse {
+			writeProblem(rw, r, c.problemFormatter, http.StatusInternalServerError, "Response does not match the API specification", err)
+			c.recordCoverage(route, http.StatusInternalServerError)
+			return
 		}
 	}
+	cw.commit()
+	c.recordCoverage(route, cw.statusCode)
 }
 
 func (c *Middleware) Validate() error {
@@ -77,17 +112,35 @@ func (c *Middleware) Validate() error {
 		strings.Join(descriptions, "\n---\n"))
 }
 
+// Report hands the recorded issues to the configured Reporter. It returns an
+// error if no Reporter was set on the Options/Checker that created this
+// Middleware.
+func (c *Middleware) Report() error {
+	if c.reporter == nil {
+		return fmt.Errorf("oaschecker: no Reporter configured")
+	}
+
+	c.mu.Lock()
+	issues := append([]ValidationIssue(nil), c.issues...)
+	c.mu.Unlock()
+
+	return c.reporter.Report(issues)
+}
+
 func (c *Middleware) addIssue(method string, url *url.URL, description string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.issues = append(c.issues, validationIssue{
+	c.issues = append(c.issues, ValidationIssue{
 		Method:      method,
 		URI:         url.String(),
 		Description: description,
 	})
 }
 
-type validationIssue struct {
+// ValidationIssue describes a single request or response that failed to
+// match the API specification, or a request whose route could not be
+// found in it.
+type ValidationIssue struct {
 	Method      string
 	URI         string
 	Description string
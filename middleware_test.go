@@ -10,7 +10,6 @@ import (
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -75,13 +74,11 @@ func TestMiddleware_ServeHTTP(t *testing.T) {
 		})
 		assert.Equal(t, 200, res.StatusCode)
 
-		assert.Equal(t, []validationIssue{
-			{
-				Method:      "GET",
-				URI:         "http://petstore.swagger.io/some-undocumented-path",
-				Description: "Route not found in specification: Does not match any server",
-			},
-		}, middleware.issues)
+		require.Len(t, middleware.issues, 1)
+		issue := middleware.issues[0]
+		assert.Equal(t, "GET", issue.Method)
+		assert.Equal(t, "http://petstore.swagger.io/some-undocumented-path", issue.URI)
+		assert.Contains(t, issue.Description, "Route not found in specification")
 	})
 
 	t.Run("should raise issue with response format", func(t *testing.T) {
@@ -101,11 +98,11 @@ func TestMiddleware_ServeHTTP(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, responseBody, string(receivedResponse), "response body should arrive unchanged")
 
-		assert.Equal(t, []validationIssue{
+		assert.Equal(t, []ValidationIssue{
 			{
 				Method:      "GET",
 				URI:         "http://petstore.swagger.io/v1/pets",
-				Description: `Invalid response: input header 'Content-Type' has unexpected value: ""`,
+				Description: `Invalid response: response header Content-Type has unexpected value: ""`,
 			},
 		}, middleware.issues)
 	})
@@ -129,15 +126,68 @@ func TestMiddleware_ServeHTTP(t *testing.T) {
 
 		assert.Equal(t, requestBody, receivedRequestBody, "request body should arrive unchanged")
 
-		assert.Equal(t, []validationIssue{
+		assert.Equal(t, []ValidationIssue{
 			{
 				Method:      "POST",
 				URI:         "http://petstore.swagger.io/v1/pets",
-				Description: `Invalid request: Request body has an error: header 'Content-Type' has unexpected value: ""`,
+				Description: `Invalid request: request body has an error: header Content-Type has unexpected value ""`,
 			},
 		}, middleware.issues)
 	})
 
+	t.Run("ModeRejectRequest should short-circuit invalid requests with a problem document", func(t *testing.T) {
+		var nextCalled bool
+		sendRequest, _ := setUpWithMode(t, router, ModeRejectRequest, func(rw http.ResponseWriter, r *http.Request) {
+			nextCalled = true
+			rw.WriteHeader(http.StatusOK)
+		})
+
+		res := sendRequest(requestInstructions{
+			Method: "POST",
+			URI:    "http://petstore.swagger.io/v1/pets",
+			Body:   []byte(`{"id": 123, "name": "Buddy"}`),
+		})
+
+		assert.False(t, nextCalled, "next handler should not be called")
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, "application/problem+json", res.Header.Get("Content-Type"))
+	})
+
+	t.Run("ModeRejectRequest should fall back to text/plain for a client that can't accept JSON", func(t *testing.T) {
+		sendRequest, _ := setUpWithMode(t, router, ModeRejectRequest, func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusOK)
+		})
+
+		res := sendRequest(requestInstructions{
+			Method:  "POST",
+			URI:     "http://petstore.swagger.io/v1/pets",
+			Body:    []byte(`{"id": 123, "name": "Buddy"}`),
+			Headers: map[string]string{"Accept": "text/plain"},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, "text/plain; charset=utf-8", res.Header.Get("Content-Type"))
+
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "Request does not match the API specification")
+	})
+
+	t.Run("ModeRejectResponse should replace an invalid response with a problem document", func(t *testing.T) {
+		sendRequest, _ := setUpWithMode(t, router, ModeRejectResponse, func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(`[{"id": 123, "name": "Buddy"}]`))
+		})
+
+		res := sendRequest(requestInstructions{
+			Method: "GET",
+			URI:    "http://petstore.swagger.io/v1/pets",
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		assert.Equal(t, "application/problem+json", res.Header.Get("Content-Type"))
+	})
+
 }
 
 type requestInstructions struct {
@@ -147,10 +197,15 @@ type requestInstructions struct {
 	Headers map[string]string
 }
 
-func setUp(t *testing.T, router *openapi3filter.Router, handler http.HandlerFunc) (requestSender, *Middleware) {
+func setUp(t *testing.T, router Router, handler http.HandlerFunc) (requestSender, *Middleware) {
+	t.Helper()
+	return setUpWithMode(t, router, ModeObserve, handler)
+}
+
+func setUpWithMode(t *testing.T, router Router, mode Mode, handler http.HandlerFunc) (requestSender, *Middleware) {
 	t.Helper()
 
-	middleware := &Middleware{router: router, next: handler}
+	middleware := &Middleware{router: router, mode: mode, next: handler}
 
 	server := httptest.NewServer(middleware)
 	t.Cleanup(server.Close)
@@ -180,13 +235,16 @@ func setUp(t *testing.T, router *openapi3filter.Router, handler http.HandlerFunc
 	}, middleware
 }
 
-func loadPetStoreRouter(t *testing.T) *openapi3filter.Router {
+func loadPetStoreRouter(t *testing.T) Router {
 	t.Helper()
 
-	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(petStore))
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(petStore))
+	require.NoError(t, err)
+
+	router, err := NewLegacyRouter(doc)
 	require.NoError(t, err)
 
-	return openapi3filter.NewRouter().WithSwagger(swagger)
+	return router
 }
 
 const petStore = `openapi: "3.0.0"
@@ -0,0 +1,30 @@
+package oaschecker
+
+// Mode controls what a Middleware does when it finds a violation of the
+// spec, beyond recording it as an issue.
+type Mode int
+
+const (
+	// ModeObserve only records issues; it never changes the traffic it
+	// validates. This is the default.
+	ModeObserve Mode = iota
+	// ModeRejectRequest additionally responds to an invalid request with a
+	// 4xx problem+json document instead of calling the next handler.
+	ModeRejectRequest
+	// ModeRejectResponse additionally replaces an invalid response with a
+	// 500 problem+json document before it reaches the client.
+	//
+	// In both reject modes, a client whose Accept header admits neither
+	// JSON nor a covering wildcard gets the problem as text/plain instead.
+	ModeRejectResponse
+	// ModeStrict combines ModeRejectRequest and ModeRejectResponse.
+	ModeStrict
+)
+
+func (m Mode) rejectsRequest() bool {
+	return m == ModeRejectRequest || m == ModeStrict
+}
+
+func (m Mode) rejectsResponse() bool {
+	return m == ModeRejectResponse || m == ModeStrict
+}
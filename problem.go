@@ -0,0 +1,76 @@
+package oaschecker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Problem is an RFC 7807 "problem detail" document, used to describe schema
+// violations to clients when a Middleware is running in a reject Mode.
+type Problem struct {
+	Type   string `json:"type,omitempty"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ProblemFormatter builds the Problem document written to the client when a
+// Middleware rejects a request or response because it violates the spec.
+type ProblemFormatter func(status int, title string, err error) Problem
+
+func defaultProblemFormatter(status int, title string, err error) Problem {
+	problem := Problem{Title: title, Status: status}
+	if err != nil {
+		problem.Detail = err.Error()
+	}
+	return problem
+}
+
+func writeProblem(rw http.ResponseWriter, r *http.Request, formatter ProblemFormatter, status int, title string, err error) {
+	if formatter == nil {
+		formatter = defaultProblemFormatter
+	}
+	problem := formatter(status, title, err)
+
+	if !acceptsProblemJSON(r) {
+		rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		rw.WriteHeader(status)
+		if problem.Detail != "" {
+			fmt.Fprintf(rw, "%s: %s\n", problem.Title, problem.Detail)
+		} else {
+			fmt.Fprintf(rw, "%s\n", problem.Title)
+		}
+		return
+	}
+
+	body, marshalErr := json.Marshal(problem)
+	if marshalErr != nil {
+		body, _ = json.Marshal(defaultProblemFormatter(status, title, err))
+	}
+
+	rw.Header().Set("Content-Type", "application/problem+json")
+	rw.WriteHeader(status)
+	rw.Write(body)
+}
+
+// acceptsProblemJSON reports whether the request's Accept header (if any)
+// admits application/problem+json, application/json, or a wildcard that
+// covers it. A request with no Accept header, per RFC 7231 §5.3.2, accepts
+// anything.
+func acceptsProblemJSON(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return true
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		switch mediaType {
+		case "*/*", "application/*", "application/json", "application/problem+json":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package oaschecker
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxCapturedBody is the default value of Options.MaxCapturedBody:
+// the number of response body bytes a capturingResponseWriter will buffer
+// for validation before giving up and reporting the body as truncated.
+const DefaultMaxCapturedBody int64 = 1 << 20 // 1 MiB
+
+// capturingResponseWriter tees bytes written by the downstream handler to
+// the real http.ResponseWriter as they arrive, so clients still see
+// streaming behavior, while accumulating a bounded prefix of the body for
+// later validation.
+type capturingResponseWriter struct {
+	http.ResponseWriter
+
+	maxCapturedBody int64
+	opaqueTypes     map[string]bool
+	// hold, when true, withholds the header and body from the underlying
+	// ResponseWriter until commit() is called, so an invalid response can
+	// still be replaced wholesale with a problem document. A held response
+	// is still bounded by maxCapturedBody, same as a streamed one.
+	hold bool
+
+	wroteHeader bool
+	committed   bool
+	statusCode  int
+	captured    bytes.Buffer
+	truncated   bool
+	opaque      bool
+}
+
+func newCapturingResponseWriter(rw http.ResponseWriter, maxCapturedBody int64, opaqueTypes map[string]bool, hold bool) *capturingResponseWriter {
+	if maxCapturedBody <= 0 {
+		maxCapturedBody = DefaultMaxCapturedBody
+	}
+
+	return &capturingResponseWriter{
+		ResponseWriter:  rw,
+		maxCapturedBody: maxCapturedBody,
+		opaqueTypes:     opaqueTypes,
+		hold:            hold,
+		statusCode:      http.StatusOK,
+	}
+}
+
+func (w *capturingResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.statusCode = statusCode
+	if w.opaqueTypes[normalizeContentType(w.Header().Get("Content-Type"))] {
+		w.opaque = true
+	}
+	// An opaque response is never held, even under a reject Mode: it's
+	// never validated, so there's nothing for commit() to replace it with,
+	// and withholding it would just turn it into an empty body.
+	if !w.hold || w.opaque {
+		w.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+func (w *capturingResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.hold && !w.opaque {
+		w.capture(p)
+		return len(p), nil
+	}
+
+	n, err := w.ResponseWriter.Write(p)
+	if n > 0 {
+		w.capture(p[:n])
+	}
+	return n, err
+}
+
+// commit flushes a held response (header and captured body) to the real
+// ResponseWriter. It is a no-op for a response that was never held, for an
+// opaque response, or once it has already been committed. It does not know
+// whether a problem document was written in its place, so it must not be
+// called after one was.
+func (w *capturingResponseWriter) commit() {
+	if !w.hold || w.committed || w.opaque {
+		return
+	}
+	w.committed = true
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	w.ResponseWriter.Write(w.captured.Bytes())
+}
+
+func (w *capturingResponseWriter) capture(p []byte) {
+	if w.opaque || w.truncated {
+		return
+	}
+
+	room := w.maxCapturedBody - int64(w.captured.Len())
+	if room <= 0 {
+		w.truncated = true
+		return
+	}
+	if int64(len(p)) > room {
+		p = p[:room]
+		w.truncated = true
+	}
+	w.captured.Write(p)
+}
+
+func (w *capturingResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *capturingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errNotHijackable
+	}
+	return hj.Hijack()
+}
+
+func (w *capturingResponseWriter) Push(target string, opts *http.PushOptions) error {
+	p, ok := w.ResponseWriter.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+	return p.Push(target, opts)
+}
+
+var errNotHijackable = errors.New("oaschecker: underlying ResponseWriter does not support http.Hijacker")
+
+// normalizeContentType strips any parameters (e.g. "; charset=utf-8") and
+// surrounding whitespace from a Content-Type header value, so it can be
+// compared against Options.OpaqueContentTypes regardless of casing or
+// parameters.
+func normalizeContentType(contentType string) string {
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
@@ -0,0 +1,172 @@
+package oaschecker
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapturingResponseWriter_streamsAndCapturesConcurrently(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := newCapturingResponseWriter(rec, DefaultMaxCapturedBody, nil, false)
+
+	w.WriteHeader(http.StatusCreated)
+	n, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	assert.Equal(t, http.StatusCreated, rec.Code, "streaming mode writes the header immediately")
+	assert.Equal(t, "hello", rec.Body.String(), "streaming mode tees bytes to the client as they arrive")
+	assert.Equal(t, "hello", w.captured.String(), "bytes are also captured for later validation")
+	assert.False(t, w.truncated)
+}
+
+func TestCapturingResponseWriter_truncatesAtMaxCapturedBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := newCapturingResponseWriter(rec, 3, nil, false)
+
+	_, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "hel", w.captured.String(), "captured bytes stop at maxCapturedBody")
+	assert.True(t, w.truncated)
+	assert.Equal(t, "hello", rec.Body.String(), "the client still receives the full body, truncation only affects validation")
+}
+
+func TestCapturingResponseWriter_hold_buffersUntilCommit(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := newCapturingResponseWriter(rec, DefaultMaxCapturedBody, nil, true)
+
+	w.WriteHeader(http.StatusBadRequest)
+	_, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 200, rec.Code, "a held response withholds its header from the client until commit")
+	assert.Empty(t, rec.Body.String(), "a held response withholds its body from the client until commit")
+	assert.Equal(t, "hello", w.captured.String())
+
+	w.commit()
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "hello", rec.Body.String())
+}
+
+func TestCapturingResponseWriter_hold_commitIsIdempotent(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := newCapturingResponseWriter(rec, DefaultMaxCapturedBody, nil, true)
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("hello"))
+	w.commit()
+	w.commit()
+
+	assert.Equal(t, "hello", rec.Body.String(), "a second commit must not duplicate the body")
+}
+
+func TestCapturingResponseWriter_opaque_observeMode(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := newCapturingResponseWriter(rec, DefaultMaxCapturedBody, map[string]bool{"text/event-stream": true}, false)
+
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write([]byte("data: ping\n\n"))
+	require.NoError(t, err)
+
+	assert.True(t, w.opaque)
+	assert.Equal(t, "data: ping\n\n", rec.Body.String(), "an opaque response still streams through to the client")
+	assert.Zero(t, w.captured.Len(), "an opaque response is never captured for validation")
+}
+
+func TestCapturingResponseWriter_opaque_rejectMode(t *testing.T) {
+	// Regression test: a reject Mode (hold=true) must not swallow an opaque
+	// response's body. Before the fix, Write took the hold branch and
+	// capture() silently no-opped because w.opaque was true, so the client
+	// got a 200 with an empty body instead of the real stream.
+	rec := httptest.NewRecorder()
+	w := newCapturingResponseWriter(rec, DefaultMaxCapturedBody, map[string]bool{"text/event-stream": true}, true)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write([]byte("data: ping\n\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "an opaque response's header is written immediately, even when held")
+	assert.Equal(t, "data: ping\n\n", rec.Body.String(), "an opaque response streams through even under a reject Mode")
+
+	w.commit()
+	assert.Equal(t, "data: ping\n\n", rec.Body.String(), "commit must not re-write an opaque response")
+}
+
+func TestCapturingResponseWriter_Flush(t *testing.T) {
+	rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	w := newCapturingResponseWriter(rec, DefaultMaxCapturedBody, nil, false)
+
+	w.Flush()
+	assert.True(t, rec.flushed, "Flush should delegate to an underlying http.Flusher")
+}
+
+func TestCapturingResponseWriter_Hijack(t *testing.T) {
+	t.Run("delegates when the underlying ResponseWriter supports it", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+		defer server.Close()
+
+		rec := &hijackRecorder{ResponseRecorder: httptest.NewRecorder(), conn: server}
+		w := newCapturingResponseWriter(rec, DefaultMaxCapturedBody, nil, false)
+
+		conn, _, err := w.Hijack()
+		require.NoError(t, err)
+		assert.Equal(t, server, conn)
+	})
+
+	t.Run("errors when the underlying ResponseWriter doesn't support it", func(t *testing.T) {
+		w := newCapturingResponseWriter(httptest.NewRecorder(), DefaultMaxCapturedBody, nil, false)
+		_, _, err := w.Hijack()
+		assert.Equal(t, errNotHijackable, err)
+	})
+}
+
+func TestCapturingResponseWriter_Push(t *testing.T) {
+	t.Run("delegates when the underlying ResponseWriter supports it", func(t *testing.T) {
+		rec := &pushRecorder{ResponseRecorder: httptest.NewRecorder()}
+		w := newCapturingResponseWriter(rec, DefaultMaxCapturedBody, nil, false)
+
+		require.NoError(t, w.Push("/style.css", nil))
+		assert.Equal(t, "/style.css", rec.pushedTarget)
+	})
+
+	t.Run("errors when the underlying ResponseWriter doesn't support it", func(t *testing.T) {
+		w := newCapturingResponseWriter(httptest.NewRecorder(), DefaultMaxCapturedBody, nil, false)
+		assert.Equal(t, http.ErrNotSupported, w.Push("/style.css", nil))
+	})
+}
+
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushed bool
+}
+
+func (r *flushRecorder) Flush() { r.flushed = true }
+
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	conn net.Conn
+}
+
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return r.conn, bufio.NewReadWriter(bufio.NewReader(r.conn), bufio.NewWriter(r.conn)), nil
+}
+
+type pushRecorder struct {
+	*httptest.ResponseRecorder
+	pushedTarget string
+}
+
+func (r *pushRecorder) Push(target string, opts *http.PushOptions) error {
+	r.pushedTarget = target
+	return nil
+}
@@ -0,0 +1,32 @@
+package oaschecker
+
+import (
+	"fmt"
+	"io"
+)
+
+// Reporter turns the issues recorded by a Middleware into some output
+// format, e.g. for consumption by a CI dashboard or code-review bot.
+type Reporter interface {
+	Report(issues []ValidationIssue) error
+}
+
+// TextReporter writes a human-readable summary of issues to Writer, one
+// issue per line.
+type TextReporter struct {
+	Writer io.Writer
+}
+
+func (r TextReporter) Report(issues []ValidationIssue) error {
+	if len(issues) == 0 {
+		_, err := fmt.Fprintln(r.Writer, "No issues were found validating the API specification.")
+		return err
+	}
+
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(r.Writer, "%v %v: %v\n", issue.Method, issue.URI, issue.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
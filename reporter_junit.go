@@ -0,0 +1,68 @@
+package oaschecker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// JUnitReporter writes issues as a JUnit XML test suite, one test case per
+// issue, so that `go test` runs in CI can surface each unspecified route or
+// invalid response as a distinct failing test.
+type JUnitReporter struct {
+	Writer io.Writer
+	// SuiteName is used as the <testsuite name="...">. Defaults to
+	// "oaschecker" when empty.
+	SuiteName string
+}
+
+func (r JUnitReporter) Report(issues []ValidationIssue) error {
+	suiteName := r.SuiteName
+	if suiteName == "" {
+		suiteName = "oaschecker"
+	}
+
+	suite := junitTestSuite{
+		Name:      suiteName,
+		Tests:     len(issues),
+		Failures:  len(issues),
+		TestCases: make([]junitTestCase, len(issues)),
+	}
+	for i, issue := range issues {
+		suite.TestCases[i] = junitTestCase{
+			Name:      fmt.Sprintf("%v %v", issue.Method, issue.URI),
+			ClassName: suiteName,
+			Failure: &junitFailure{
+				Message: issue.Description,
+				Content: issue.Description,
+			},
+		}
+	}
+
+	if _, err := io.WriteString(r.Writer, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(r.Writer)
+	enc.Indent("", "  ")
+	return enc.Encode(suite)
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
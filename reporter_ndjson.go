@@ -0,0 +1,22 @@
+package oaschecker
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// NDJSONReporter writes one JSON object per issue, newline-delimited, to
+// Writer. This is convenient for feeding into log-aggregation pipelines.
+type NDJSONReporter struct {
+	Writer io.Writer
+}
+
+func (r NDJSONReporter) Report(issues []ValidationIssue) error {
+	enc := json.NewEncoder(r.Writer)
+	for _, issue := range issues {
+		if err := enc.Encode(issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package oaschecker
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextReporter(t *testing.T) {
+	t.Run("reports no issues", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (TextReporter{Writer: &buf}).Report(nil))
+		assert.Equal(t, "No issues were found validating the API specification.\n", buf.String())
+	})
+
+	t.Run("reports one line per issue", func(t *testing.T) {
+		var buf bytes.Buffer
+		issues := []ValidationIssue{
+			{Method: "GET", URI: "http://example.com/pets", Description: "Invalid response: boom"},
+			{Method: "POST", URI: "http://example.com/pets", Description: "Invalid request: boom"},
+		}
+		require.NoError(t, (TextReporter{Writer: &buf}).Report(issues))
+		assert.Equal(t,
+			"GET http://example.com/pets: Invalid response: boom\n"+
+				"POST http://example.com/pets: Invalid request: boom\n",
+			buf.String())
+	})
+}
+
+func TestNDJSONReporter(t *testing.T) {
+	issues := []ValidationIssue{
+		{Method: "GET", URI: "http://example.com/pets", Description: "Invalid response: boom"},
+		{Method: "POST", URI: "http://example.com/pets", Description: "Invalid request: boom"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, (NDJSONReporter{Writer: &buf}).Report(issues))
+
+	dec := json.NewDecoder(&buf)
+	var decoded []ValidationIssue
+	for dec.More() {
+		var issue ValidationIssue
+		require.NoError(t, dec.Decode(&issue))
+		decoded = append(decoded, issue)
+	}
+	assert.Equal(t, issues, decoded)
+}
+
+func TestJUnitReporter(t *testing.T) {
+	issues := []ValidationIssue{
+		{Method: "GET", URI: "http://example.com/pets", Description: "Invalid response: boom"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, (JUnitReporter{Writer: &buf}).Report(issues))
+
+	var suite junitTestSuite
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suite))
+
+	assert.Equal(t, "oaschecker", suite.Name)
+	assert.Equal(t, 1, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	require.Len(t, suite.TestCases, 1)
+	assert.Equal(t, "GET http://example.com/pets", suite.TestCases[0].Name)
+	require.NotNil(t, suite.TestCases[0].Failure)
+	assert.Equal(t, "Invalid response: boom", suite.TestCases[0].Failure.Message)
+}
+
+func TestJUnitReporter_customSuiteName(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (JUnitReporter{Writer: &buf, SuiteName: "api-contract"}).Report(nil))
+
+	var suite junitTestSuite
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suite))
+	assert.Equal(t, "api-contract", suite.Name)
+	assert.Equal(t, 0, suite.Tests)
+}
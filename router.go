@@ -0,0 +1,35 @@
+package oaschecker
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// Router matches an incoming request to the OpenAPI operation that
+// describes it. The routers built by kin-openapi's routers/legacy and
+// routers/gorillamux packages already satisfy this interface, as does
+// anything returned by the adapters in this package.
+type Router interface {
+	FindRoute(req *http.Request) (*routers.Route, map[string]string, error)
+}
+
+// NewLegacyRouter builds the default Router, backed by kin-openapi's
+// routers/legacy package. It matches the spec's `servers` entries against
+// the request's scheme/host, so it requires the API to be served from one
+// of those exact base paths.
+func NewLegacyRouter(doc *openapi3.T) (Router, error) {
+	return legacy.NewRouter(doc)
+}
+
+// NewGorillaMuxRouter builds a Router backed by kin-openapi's
+// routers/gorillamux package, which matches requests using gorilla/mux's
+// path-template syntax rather than by comparing against `servers` entries.
+// This is useful when the API is mounted at a base path that differs from
+// the one declared in the spec.
+func NewGorillaMuxRouter(doc *openapi3.T) (Router, error) {
+	return gorillamux.NewRouter(doc)
+}
@@ -0,0 +1,99 @@
+package oaschecker
+
+import (
+	"io/fs"
+	"net/url"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Source loads an OpenAPI document, resolving any `$ref`s relative to
+// wherever it came from. Use FileSource, URLSource, DataSource or FSSource
+// to build one; Options.File is a thin wrapper around FileSource for
+// backwards compatibility.
+type Source interface {
+	load(loader *openapi3.Loader) (*openapi3.T, error)
+}
+
+type fileSource struct {
+	path string
+}
+
+// FileSource loads the spec at path from the local filesystem. `$ref`s are
+// resolved relative to its directory.
+func FileSource(path string) Source {
+	return fileSource{path: path}
+}
+
+func (s fileSource) load(loader *openapi3.Loader) (*openapi3.T, error) {
+	return loader.LoadFromFile(s.path)
+}
+
+type urlSource struct {
+	rawURL string
+}
+
+// URLSource fetches the spec over HTTP(S). `$ref`s are resolved relative to
+// rawURL, so it can pull in sibling documents served from the same host.
+func URLSource(rawURL string) Source {
+	return urlSource{rawURL: rawURL}
+}
+
+func (s urlSource) load(loader *openapi3.Loader) (*openapi3.T, error) {
+	parsed, err := url.Parse(s.rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadFromURI(parsed)
+}
+
+type dataSource struct {
+	data    []byte
+	baseURI string
+}
+
+// DataSource loads the spec from an in-memory byte slice, e.g. one embedded
+// with `go:embed` or assembled at runtime. baseURI is used to resolve any
+// `$ref`s in data and may be empty if it has none.
+func DataSource(data []byte, baseURI string) Source {
+	return dataSource{data: data, baseURI: baseURI}
+}
+
+func (s dataSource) load(loader *openapi3.Loader) (*openapi3.T, error) {
+	if s.baseURI == "" {
+		return loader.LoadFromData(s.data)
+	}
+
+	base, err := url.Parse(s.baseURI)
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadFromDataWithPath(s.data, base)
+}
+
+type fsSource struct {
+	fsys fs.FS
+	path string
+}
+
+// FSSource loads the spec at path from an fs.FS, e.g. one produced by
+// `go:embed`. `$ref`s are resolved relative to path within fsys.
+func FSSource(fsys fs.FS, path string) Source {
+	return fsSource{fsys: fsys, path: path}
+}
+
+func (s fsSource) load(loader *openapi3.Loader) (*openapi3.T, error) {
+	data, err := fs.ReadFile(s.fsys, s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sibling $refs must also be resolved against fsys, not the OS
+	// filesystem the default loader would otherwise read from.
+	loader.ReadFromURIFunc = func(_ *openapi3.Loader, uri *url.URL) ([]byte, error) {
+		return fs.ReadFile(s.fsys, uri.Path)
+	}
+
+	base := &url.URL{Path: s.path}
+	return loader.LoadFromDataWithPath(data, base)
+}
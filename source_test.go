@@ -0,0 +1,105 @@
+package oaschecker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const petStoreWithRef = `openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Swagger Petstore
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: A paged array of pets
+          content:
+            application/json:
+              schema:
+                $ref: "schemas.yaml#/Pets"
+`
+
+const petStoreSchemas = `Pets:
+  type: array
+  items:
+    $ref: "#/Pet"
+Pet:
+  type: object
+  required:
+    - id
+  properties:
+    id:
+      type: integer
+`
+
+func loadSource(t *testing.T, source Source) *openapi3.T {
+	t.Helper()
+	doc, err := source.load(&openapi3.Loader{IsExternalRefsAllowed: true})
+	require.NoError(t, err)
+	return doc
+}
+
+func TestFileSource(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(petStoreWithRef), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas.yaml"), []byte(petStoreSchemas), 0o644))
+
+	doc := loadSource(t, FileSource(filepath.Join(dir, "openapi.yaml")))
+	assert.Equal(t, "Swagger Petstore", doc.Info.Title)
+	assert.NotNil(t, doc.Paths.Find("/pets"), "sibling $ref should resolve relative to the file's directory")
+}
+
+func TestURLSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openapi.yaml":
+			rw.Write([]byte(petStoreWithRef))
+		case "/schemas.yaml":
+			rw.Write([]byte(petStoreSchemas))
+		default:
+			http.NotFound(rw, r)
+		}
+	}))
+	defer server.Close()
+
+	doc := loadSource(t, URLSource(server.URL+"/openapi.yaml"))
+	assert.Equal(t, "Swagger Petstore", doc.Info.Title)
+	assert.NotNil(t, doc.Paths.Find("/pets"), "sibling $ref should resolve relative to rawURL")
+}
+
+func TestDataSource(t *testing.T) {
+	t.Run("without a baseURI", func(t *testing.T) {
+		doc := loadSource(t, DataSource([]byte(petStore), ""))
+		assert.Equal(t, "Swagger Petstore", doc.Info.Title)
+	})
+
+	t.Run("with a baseURI resolving sibling refs", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas.yaml"), []byte(petStoreSchemas), 0o644))
+
+		doc := loadSource(t, DataSource([]byte(petStoreWithRef), filepath.Join(dir, "openapi.yaml")))
+		assert.NotNil(t, doc.Paths.Find("/pets"))
+	})
+}
+
+func TestFSSource(t *testing.T) {
+	fsys := fstest.MapFS{
+		"openapi.yaml": {Data: []byte(petStoreWithRef)},
+		"schemas.yaml": {Data: []byte(petStoreSchemas)},
+	}
+
+	doc := loadSource(t, FSSource(fsys, "openapi.yaml"))
+	assert.Equal(t, "Swagger Petstore", doc.Info.Title)
+	assert.NotNil(t, doc.Paths.Find("/pets"), "sibling $ref should resolve against fsys, not the OS filesystem")
+}